@@ -0,0 +1,53 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/spf13/viper"
+)
+
+// Config holds everything the app needs to wire up its dependencies.
+type Config struct {
+	DBDriver string `mapstructure:"DB_DRIVER"`
+	DBSource string `mapstructure:"DB_SOURCE"`
+	Port     string `mapstructure:"PORT"`
+	// JWTSecret signs and verifies auth tokens.
+	JWTSecret  string `mapstructure:"JWT_SECRET"`
+	BcryptCost int    `mapstructure:"BCRYPT_COST"`
+}
+
+// LoadConfig reads app.env from path, if present, and returns the resulting
+// Config. Environment variables of the same name take precedence over values
+// from the file and are used on their own when the file is missing.
+func LoadConfig(path string) (*Config, error) {
+	viper.AddConfigPath(path)
+	viper.SetConfigName("app")
+	viper.SetConfigType("env")
+
+	viper.SetDefault("DB_DRIVER", "postgres")
+	viper.SetDefault("PORT", "8080")
+	viper.SetDefault("BCRYPT_COST", 10)
+
+	viper.AutomaticEnv()
+	// Keys with no default must be bound explicitly for Unmarshal to see
+	// them when app.env is absent.
+	viper.BindEnv("DB_SOURCE")
+	viper.BindEnv("JWT_SECRET")
+
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, err
+		}
+	}
+
+	var cfg Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.JWTSecret == "" {
+		return nil, errors.New("JWT_SECRET must be set (refusing to start with an empty JWT signing secret)")
+	}
+
+	return &cfg, nil
+}
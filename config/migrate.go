@@ -0,0 +1,54 @@
+package config
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+
+	"github.com/Anggiprayoga28/learning-golang/repositories"
+)
+
+// migrationsPath returns where golang-migrate looks for *.up.sql / *.down.sql
+// files for driver. Postgres and MySQL DDL isn't portable (index syntax,
+// timestamp defaults, auto-increment), so each driver gets its own directory
+// under migrations/ rather than sharing one set of files.
+func migrationsPath(driver string) string {
+	if driver == repositories.DriverMySQL {
+		return "file://migrations/mysql"
+	}
+	return "file://migrations/postgres"
+}
+
+// RunMigrations applies any pending migrations for driver to db and returns
+// the migrate.Migrate instance so callers can inspect the resulting schema
+// version (e.g. from a health check).
+func RunMigrations(db *sql.DB, driver string) (*migrate.Migrate, error) {
+	var dbDriver database.Driver
+	var err error
+
+	switch driver {
+	case repositories.DriverMySQL:
+		dbDriver, err = mysql.WithInstance(db, &mysql.Config{})
+	default:
+		dbDriver, err = postgres.WithInstance(db, &postgres.Config{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize migration driver: %v", err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(migrationsPath(driver), driver, dbDriver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize migrations: %v", err)
+	}
+
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return nil, fmt.Errorf("failed to run migrations: %v", err)
+	}
+
+	return m, nil
+}
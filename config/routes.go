@@ -0,0 +1,48 @@
+package config
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-migrate/migrate/v4"
+
+	"github.com/Anggiprayoga28/learning-golang/controllers"
+)
+
+// RegisterRoutes wires up every route the app serves. migrator is used by
+// /healthz to report the currently applied schema version.
+func RegisterRoutes(r *gin.Engine, userController *controllers.UserController, authController *controllers.AuthController, migrator *migrate.Migrate) {
+	r.StaticFile("/", "./templates/index.html")
+
+	r.GET("/healthz", healthzHandler(migrator))
+
+	r.POST("/register", authController.Register)
+	r.POST("/login", authController.Login)
+
+	r.GET("/users", userController.GetUsers)
+
+	authorized := r.Group("/users")
+	authorized.Use(authController.RequireAuth())
+	{
+		authorized.POST("", userController.CreateUser)
+		authorized.PUT("/:id", userController.UpdateUser)
+		authorized.DELETE("/:id", userController.DeleteUser)
+	}
+}
+
+// healthzHandler reports the schema version currently applied by migrator.
+func healthzHandler(migrator *migrate.Migrate) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, dirty, err := migrator.Version()
+		if err != nil && err != migrate.ErrNilVersion {
+			c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":            "ok",
+			"migration_version": version,
+			"dirty":             dirty,
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Anggiprayoga28/learning-golang/models"
+	"github.com/Anggiprayoga28/learning-golang/repositories"
+)
+
+const tokenTTL = 72 * time.Hour
+
+// AuthController handles registration, login, and the JWT middleware that
+// gates the mutating /users routes. It takes only the primitive config
+// values it needs rather than *config.Config, so controllers doesn't have
+// to import config (which itself imports controllers to register routes).
+type AuthController struct {
+	repo       repositories.UserRepository
+	jwtSecret  string
+	bcryptCost int
+}
+
+// NewAuthController builds an AuthController backed by repo, signing tokens
+// with jwtSecret and hashing passwords at bcryptCost.
+func NewAuthController(repo repositories.UserRepository, jwtSecret string, bcryptCost int) *AuthController {
+	return &AuthController{repo: repo, jwtSecret: jwtSecret, bcryptCost: bcryptCost}
+}
+
+// authClaims is the payload embedded in issued JWTs.
+type authClaims struct {
+	UserID int `json:"userId"`
+	jwt.RegisteredClaims
+}
+
+// Register handles POST /register.
+func (ac *AuthController) Register(c *gin.Context) {
+	var input models.RegisterInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(input.Password), ac.bcryptCost)
+	if err != nil {
+		log.Printf("Failed to hash password: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process password"})
+		return
+	}
+
+	user := &models.User{
+		Name:       input.Name,
+		Department: input.Department,
+		Email:      input.Email,
+		Password:   string(hashed),
+	}
+	id, err := ac.repo.Create(c.Request.Context(), user)
+	if err != nil {
+		log.Printf("Failed to insert user into database: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// Login handles POST /login, returning a signed JWT on success.
+func (ac *AuthController) Login(c *gin.Context) {
+	var input models.LoginInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	user, err := ac.repo.GetByEmail(c.Request.Context(), input.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+			return
+		}
+		log.Printf("Failed to look up user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+		return
+	}
+
+	token, err := ac.issueToken(user.ID)
+	if err != nil {
+		log.Printf("Failed to issue token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+// RequireAuth parses the Authorization: Bearer <token> header, verifies its
+// signature, and stashes the caller's user ID in the context under "userId".
+// Requests without a valid token are rejected with 401.
+func (ac *AuthController) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or malformed Authorization header"})
+			return
+		}
+
+		claims := &authClaims{}
+		token, err := jwt.ParseWithClaims(parts[1], claims, func(t *jwt.Token) (interface{}, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+			}
+			return []byte(ac.jwtSecret), nil
+		})
+		if err != nil || !token.Valid {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			return
+		}
+
+		c.Set("userId", claims.UserID)
+		c.Next()
+	}
+}
+
+func (ac *AuthController) issueToken(userID int) (string, error) {
+	claims := authClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(ac.jwtSecret))
+}
@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Anggiprayoga28/learning-golang/models"
+	"github.com/Anggiprayoga28/learning-golang/repositories"
+)
+
+// UserController exposes the CRUD handlers for /users.
+type UserController struct {
+	repo repositories.UserRepository
+}
+
+// NewUserController builds a UserController backed by repo.
+func NewUserController(repo repositories.UserRepository) *UserController {
+	return &UserController{repo: repo}
+}
+
+const (
+	defaultUsersLimit = 50
+	maxUsersLimit     = 1000
+)
+
+var allowedUserSortColumns = map[string]bool{
+	"id":         true,
+	"name":       true,
+	"department": true,
+	"email":      true,
+}
+
+// GetUsers handles GET /users, supporting pagination via limit/offset,
+// sorting via sort_column/sort_order, and filtering via department/q.
+func (uc *UserController) GetUsers(c *gin.Context) {
+	limit := defaultUsersLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxUsersLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be an integer between 1 and %d", maxUsersLimit)})
+			return
+		}
+		limit = parsed
+	}
+
+	offset := 0
+	if raw := c.Query("offset"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		offset = parsed
+	}
+
+	sortColumn := c.DefaultQuery("sort_column", "id")
+	if !allowedUserSortColumns[sortColumn] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort_column must be one of id, name, department, email"})
+		return
+	}
+
+	sortOrder := strings.ToLower(c.DefaultQuery("sort_order", "asc"))
+	if sortOrder != "asc" && sortOrder != "desc" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort_order must be asc or desc"})
+		return
+	}
+
+	params := repositories.ListUsersParams{
+		Limit:      limit,
+		Offset:     offset,
+		SortColumn: sortColumn,
+		SortOrder:  sortOrder,
+		Department: c.Query("department"),
+		Query:      c.Query("q"),
+	}
+
+	users, total, err := uc.repo.List(c.Request.Context(), params)
+	if err != nil {
+		log.Printf("Error fetching users: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": users, "total": total, "limit": limit, "offset": offset})
+}
+
+// CreateUser handles POST /users.
+func (uc *UserController) CreateUser(c *gin.Context) {
+	var input models.UserInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	user := &models.User{Name: input.Name, Department: input.Department, Email: input.Email}
+	id, err := uc.repo.Create(c.Request.Context(), user)
+	if err != nil {
+		log.Printf("Failed to insert user into database: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// UpdateUser handles PUT /users/:id. The caller must re-confirm their own
+// password before the record is changed.
+func (uc *UserController) UpdateUser(c *gin.Context) {
+	id, err := parseUserID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !uc.requireSelf(c, id) {
+		return
+	}
+
+	var input struct {
+		models.UserInput
+		Password string `json:"password" binding:"required"`
+	}
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	if !uc.verifyCallerPassword(c, input.Password) {
+		return
+	}
+
+	user := &models.User{ID: id, Name: input.Name, Department: input.Department, Email: input.Email}
+	if err := uc.repo.Update(c.Request.Context(), user); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		log.Printf("Failed to update user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User updated"})
+}
+
+// DeleteUser handles DELETE /users/:id. The caller must re-confirm their own
+// password before the record is removed.
+func (uc *UserController) DeleteUser(c *gin.Context) {
+	id, err := parseUserID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !uc.requireSelf(c, id) {
+		return
+	}
+
+	var body struct {
+		Password string `json:"password" binding:"required"`
+	}
+	if !bindJSON(c, &body) {
+		return
+	}
+
+	if !uc.verifyCallerPassword(c, body.Password) {
+		return
+	}
+
+	if err := uc.repo.Delete(c.Request.Context(), id); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		log.Printf("Failed to delete user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted"})
+}
+
+// requireSelf rejects the request unless the authenticated caller is the
+// same user as the :id being modified, preventing one account from using its
+// own valid password to rewrite or delete another account's record.
+func (uc *UserController) requireSelf(c *gin.Context, id int) bool {
+	callerID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authentication context"})
+		return false
+	}
+
+	if callerID.(int) != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "cannot modify another user's record"})
+		return false
+	}
+
+	return true
+}
+
+// verifyCallerPassword re-checks the authenticated caller's password before a
+// destructive change is applied, mirroring the OpenBills pattern of requiring
+// reauthentication for sensitive writes.
+func (uc *UserController) verifyCallerPassword(c *gin.Context, password string) bool {
+	callerID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authentication context"})
+		return false
+	}
+
+	caller, err := uc.repo.GetByID(c.Request.Context(), callerID.(int))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "caller account no longer exists"})
+			return false
+		}
+		log.Printf("Failed to look up caller: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(caller.Password), []byte(password)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "password confirmation failed"})
+		return false
+	}
+
+	return true
+}
+
+func parseUserID(c *gin.Context) (int, error) {
+	return strconv.Atoi(c.Param("id"))
+}
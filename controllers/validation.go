@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// bindJSON binds body into dst and, on failure, writes the appropriate error
+// response: 422 with a per-field breakdown for binding-tag validation
+// failures, 400 for anything else (malformed JSON, wrong types, ...).
+func bindJSON(c *gin.Context, dst interface{}) bool {
+	if err := c.ShouldBindJSON(dst); err != nil {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":  true,
+				"fields": translateValidationErrors(validationErrs),
+			})
+			return false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// translateValidationErrors turns validator.ValidationErrors into a
+// field -> human-readable message map.
+func translateValidationErrors(errs validator.ValidationErrors) map[string]string {
+	fields := make(map[string]string, len(errs))
+	for _, fe := range errs {
+		fields[strings.ToLower(fe.Field())] = translateValidationTag(fe)
+	}
+	return fields
+}
+
+func translateValidationTag(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "required"
+	case "email":
+		return "must be a valid email"
+	case "min":
+		return fmt.Sprintf("must be at least %s characters", fe.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s characters", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fe.Param())
+	default:
+		return "invalid value"
+	}
+}
@@ -2,46 +2,24 @@ package main
 
 import (
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
-	"net/http"
-	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
-)
-
-func getEnv(key, fallback string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return fallback
-}
 
-var db *sql.DB
-
-func initDB() error {
-	var psqlInfo string
-
-	if databaseURL := os.Getenv("DATABASE_URL"); databaseURL != "" {
-		psqlInfo = databaseURL
-		log.Println("Using DATABASE_URL from environment")
-	} else {
-		host := getEnv("DB_HOST", "learning-postgres")
-		port := getEnv("DB_PORT", "5432")
-		user := getEnv("DB_USER", "postgres")
-		password := getEnv("DB_PASSWORD", "mysecretpassword")
-		dbname := getEnv("DB_NAME", "learningdb")
-		psqlInfo = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=require",
-			host, port, user, password, dbname)
-		log.Println("Using individual DB environment variables")
-	}
+	"github.com/Anggiprayoga28/learning-golang/config"
+	"github.com/Anggiprayoga28/learning-golang/controllers"
+	"github.com/Anggiprayoga28/learning-golang/repositories"
+)
 
-	var err error
-	db, err = sql.Open("postgres", psqlInfo)
+func initDB(cfg *config.Config) (*sql.DB, error) {
+	db, err := sql.Open(cfg.DBDriver, cfg.DBSource)
 	if err != nil {
-		return fmt.Errorf("failed to open database connection: %v", err)
+		return nil, fmt.Errorf("failed to open database connection: %v", err)
 	}
 
 	db.SetMaxOpenConns(10)
@@ -54,140 +32,49 @@ func initDB() error {
 		err = db.Ping()
 		if err == nil {
 			log.Println("Successfully connected to the database!")
-			return nil
+			return db, nil
 		}
 		log.Printf("Failed to ping database (attempt %d/%d): %v", i+1, maxRetries, err)
 		time.Sleep(time.Duration(i+1) * time.Second)
 	}
 
-	return fmt.Errorf("failed to connect to database after %d attempts: %v", maxRetries, err)
+	return nil, fmt.Errorf("failed to connect to database after %d attempts: %v", maxRetries, err)
 }
 
 func main() {
-	// Initialize database
-	if err := initDB(); err != nil {
-		log.Fatalf("Database initialization failed: %v", err)
-	}
-	defer db.Close()
-
-	// Initialize Gin router
-	gin.SetMode(gin.ReleaseMode)
-	r := gin.Default()
+	migrateOnly := flag.Bool("migrate-only", false, "run pending migrations then exit, without starting the server")
+	flag.Parse()
 
-	// Serve static HTML file from templates directory
-	r.StaticFile("/", "./templates/index.html")
-
-	r.GET("/users", func(c *gin.Context) {
-		users, err := getUsersFromDB()
-		if err != nil {
-			log.Printf("Error fetching users: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusOK, users)
-	})
-
-	r.POST("/users", createUser)
-	r.PUT("/users/:id", updateUser)
-	r.DELETE("/users/:id", deleteUser)
-
-	port := getEnv("PORT", "8080")
-	log.Printf("Starting server on port %s", port)
-	r.Run(":" + port)
-}
-
-// Handler to create a new user
-func createUser(c *gin.Context) {
-	var user struct {
-		Name       string `json:"name"`
-		Department string `json:"department"`
-		Email      string `json:"email"`
-	}
-	if err := c.ShouldBindJSON(&user); err != nil {
-		log.Printf("Failed to bind JSON: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	var userID int
-	err := db.QueryRow(`INSERT INTO users (name, department, email) VALUES ($1, $2, $3) RETURNING id`,
-		user.Name, user.Department, user.Email).Scan(&userID)
+	cfg, err := config.LoadConfig(".")
 	if err != nil {
-		log.Printf("Failed to insert user into database: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	c.JSON(http.StatusOK, gin.H{"id": userID})
-}
-
-// Handler to update a user
-func updateUser(c *gin.Context) {
-	id := c.Param("id")
-	var user struct {
-		Name       string `json:"name"`
-		Department string `json:"department"`
-		Email      string `json:"email"`
-	}
-	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	result, err := db.Exec("UPDATE users SET name = $1, department = $2, email = $3 WHERE id = $4",
-		user.Name, user.Department, user.Email, id)
+	db, err := initDB(cfg)
 	if err != nil {
-		log.Printf("Failed to update user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-		return
+		log.Fatalf("Database initialization failed: %v", err)
 	}
+	defer db.Close()
 
-	c.JSON(http.StatusOK, gin.H{"message": "User updated"})
-}
-
-// Handler to delete a user
-func deleteUser(c *gin.Context) {
-	id := c.Param("id")
-	result, err := db.Exec("DELETE FROM users WHERE id = $1", id)
+	migrator, err := config.RunMigrations(db, cfg.DBDriver)
 	if err != nil {
-		log.Printf("Failed to delete user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		log.Fatalf("Migration failed: %v", err)
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	if *migrateOnly {
+		log.Println("Migrations applied, exiting (--migrate-only)")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "User deleted"})
-}
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.Default()
 
-// Fetch all users from the database
-func getUsersFromDB() ([]map[string]interface{}, error) {
-	rows, err := db.Query("SELECT id, name, department, email FROM users")
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var users []map[string]interface{}
-	for rows.Next() {
-		var id int
-		var name, department, email string
-		err = rows.Scan(&id, &name, &department, &email)
-		if err != nil {
-			return nil, err
-		}
-		users = append(users, gin.H{"id": id, "name": name, "department": department, "email": email})
-	}
+	userRepo := repositories.NewUserRepository(db, cfg.DBDriver)
+	userController := controllers.NewUserController(userRepo)
+	authController := controllers.NewAuthController(userRepo, cfg.JWTSecret, cfg.BcryptCost)
+
+	config.RegisterRoutes(r, userController, authController, migrator)
 
-	return users, nil
+	log.Printf("Starting server on port %s", cfg.Port)
+	r.Run(":" + cfg.Port)
 }
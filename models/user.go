@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// User is the canonical representation of a row in the users table.
+type User struct {
+	ID         int       `json:"id" db:"id"`
+	Name       string    `json:"name" db:"name"`
+	Department string    `json:"department" db:"department"`
+	Email      string    `json:"email" db:"email"`
+	Password   string    `json:"-" db:"password"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// UserInput is the payload accepted by the create/update user endpoints.
+type UserInput struct {
+	Name       string `json:"name" binding:"required,min=2,max=100"`
+	Department string `json:"department" binding:"required,oneof=Engineering Sales HR Marketing Finance"`
+	Email      string `json:"email" binding:"required,email"`
+}
+
+// RegisterInput is the payload accepted by POST /register.
+type RegisterInput struct {
+	Name       string `json:"name" binding:"required,min=2,max=100"`
+	Department string `json:"department" binding:"required,oneof=Engineering Sales HR Marketing Finance"`
+	Email      string `json:"email" binding:"required,email"`
+	Password   string `json:"password" binding:"required,min=8"`
+}
+
+// LoginInput is the payload accepted by POST /login.
+type LoginInput struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
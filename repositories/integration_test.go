@@ -0,0 +1,125 @@
+//go:build integration
+
+// Dual-driver integration matrix for the repository layer. Unlike the unit
+// tests in user_repository_test.go (which only check the generated SQL
+// text), these run Create/List/Update/Delete against live Postgres and
+// MySQL instances, exercising the RETURNING id and LastInsertId paths for
+// real. Skipped unless the corresponding DSN env var is set; see
+// docker-compose.test.yml for how to stand up both databases.
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+
+	"github.com/Anggiprayoga28/learning-golang/models"
+)
+
+const createUsersTablePostgres = `
+CREATE TABLE IF NOT EXISTS users (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(100) NOT NULL,
+	department VARCHAR(100) NOT NULL,
+	email VARCHAR(255) NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT now(),
+	updated_at TIMESTAMP NOT NULL DEFAULT now()
+)`
+
+const createUsersTableMySQL = `
+CREATE TABLE IF NOT EXISTS users (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	name VARCHAR(100) NOT NULL,
+	department VARCHAR(100) NOT NULL,
+	email VARCHAR(255) NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+func TestIntegration_Postgres(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN not set, skipping Postgres integration test")
+	}
+	runDriverMatrix(t, DriverPostgres, dsn, createUsersTablePostgres)
+}
+
+func TestIntegration_MySQL(t *testing.T) {
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN not set, skipping MySQL integration test")
+	}
+	runDriverMatrix(t, DriverMySQL, dsn, createUsersTableMySQL)
+}
+
+// runDriverMatrix exercises the full CRUD surface of UserRepository against a
+// live database reachable at dsn.
+func runDriverMatrix(t *testing.T, driver, dsn, createTableSQL string) {
+	t.Helper()
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", driver, err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		t.Fatalf("failed to ping %s: %v", driver, err)
+	}
+
+	if _, err := db.Exec(createTableSQL); err != nil {
+		t.Fatalf("failed to create users table: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DROP TABLE IF EXISTS users")
+	})
+
+	repo := NewUserRepository(db, driver)
+	ctx := context.Background()
+
+	user := &models.User{Name: "Ada Lovelace", Department: "Engineering", Email: "ada@example.com", Password: "hashed"}
+	id, err := repo.Create(ctx, user)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if id <= 0 {
+		t.Fatalf("expected a positive ID, got %d", id)
+	}
+
+	fetched, err := repo.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if fetched.Email != user.Email {
+		t.Errorf("GetByID email = %q, want %q", fetched.Email, user.Email)
+	}
+
+	fetched.Name = "Ada King"
+	if err := repo.Update(ctx, fetched); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	users, total, err := repo.List(ctx, ListUsersParams{Limit: 10, Offset: 0, SortColumn: "id", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if total != 1 || len(users) != 1 {
+		t.Fatalf("expected 1 user, got total=%d len=%d", total, len(users))
+	}
+	if users[0].Name != "Ada King" {
+		t.Errorf("List returned Name = %q, want %q", users[0].Name, "Ada King")
+	}
+
+	if err := repo.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, id); err != sql.ErrNoRows {
+		t.Errorf("expected sql.ErrNoRows after delete, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import "fmt"
+
+// Supported database drivers, matching the driver names registered with
+// database/sql by lib/pq and go-sql-driver/mysql respectively.
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+)
+
+// placeholderFunc returns the SQL placeholder token for the nth (1-indexed)
+// bind argument in the dialect used by driver, so the same CRUD SQL can be
+// built against either Postgres ($1, $2, ...) or MySQL (?, ?, ...).
+func placeholderFunc(driver string) func(n int) string {
+	if driver == DriverMySQL {
+		return func(n int) string { return "?" }
+	}
+	return func(n int) string { return fmt.Sprintf("$%d", n) }
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import "testing"
+
+func TestPlaceholderFunc_Postgres(t *testing.T) {
+	ph := placeholderFunc(DriverPostgres)
+
+	if got, want := ph(1), "$1"; got != want {
+		t.Errorf("ph(1) = %q, want %q", got, want)
+	}
+	if got, want := ph(3), "$3"; got != want {
+		t.Errorf("ph(3) = %q, want %q", got, want)
+	}
+}
+
+func TestPlaceholderFunc_MySQL(t *testing.T) {
+	ph := placeholderFunc(DriverMySQL)
+
+	for n := 1; n <= 3; n++ {
+		if got, want := ph(n), "?"; got != want {
+			t.Errorf("ph(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Anggiprayoga28/learning-golang/models"
+)
+
+// ErrInvalidSortColumn is returned when ListUsersParams.SortColumn isn't one
+// of the whitelisted columns.
+var ErrInvalidSortColumn = errors.New("invalid sort column")
+
+// ErrInvalidSortOrder is returned when ListUsersParams.SortOrder isn't "asc"
+// or "desc".
+var ErrInvalidSortOrder = errors.New("invalid sort order")
+
+// userSortColumns whitelists the columns callers may sort by, preventing the
+// sort_column query parameter from becoming a SQL injection vector.
+var userSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"department": "department",
+	"email":      "email",
+}
+
+// ListUsersParams controls pagination, sorting, and filtering for List.
+type ListUsersParams struct {
+	Limit      int
+	Offset     int
+	SortColumn string
+	SortOrder  string
+	Department string
+	Query      string
+}
+
+// UserRepository abstracts persistence for models.User so controllers can be
+// tested against a mock instead of a live database.
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) (int, error)
+	List(ctx context.Context, params ListUsersParams) ([]models.User, int, error)
+	GetByID(ctx context.Context, id int) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, id int) error
+}
+
+type userRepository struct {
+	db     *sql.DB
+	driver string
+	ph     func(n int) string
+}
+
+// NewUserRepository builds a UserRepository backed by db, generating SQL in
+// the placeholder dialect of driver (DriverPostgres or DriverMySQL).
+func NewUserRepository(db *sql.DB, driver string) UserRepository {
+	return &userRepository{db: db, driver: driver, ph: placeholderFunc(driver)}
+}
+
+// buildCreateQuery returns the INSERT statement for a new user, in the
+// placeholder dialect of r.driver. Postgres uses RETURNING id to get the new
+// row's ID back from the same round-trip; MySQL has no RETURNING clause, so
+// the caller falls back to LastInsertId instead.
+func (r *userRepository) buildCreateQuery() string {
+	query := fmt.Sprintf(
+		`INSERT INTO users (name, department, email, password) VALUES (%s, %s, %s, %s)`,
+		r.ph(1), r.ph(2), r.ph(3), r.ph(4),
+	)
+	if r.driver == DriverMySQL {
+		return query
+	}
+	return query + " RETURNING id"
+}
+
+func (r *userRepository) Create(ctx context.Context, user *models.User) (int, error) {
+	query := r.buildCreateQuery()
+	args := []interface{}{user.Name, user.Department, user.Email, user.Password}
+
+	if r.driver == DriverMySQL {
+		result, err := r.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
+		return int(id), nil
+	}
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	return id, err
+}
+
+// buildListQuery builds the COUNT(*) and SELECT statements (plus their bind
+// args) backing List, in the placeholder dialect of r.driver. Pulled out of
+// List so the query-building logic can be unit tested without a live DB.
+func (r *userRepository) buildListQuery(params ListUsersParams) (countQuery string, countArgs []interface{}, listQuery string, listArgs []interface{}, err error) {
+	column, ok := userSortColumns[params.SortColumn]
+	if !ok {
+		return "", nil, "", nil, ErrInvalidSortColumn
+	}
+	order := strings.ToLower(params.SortOrder)
+	if order != "asc" && order != "desc" {
+		return "", nil, "", nil, ErrInvalidSortOrder
+	}
+
+	var conditions []string
+	var args []interface{}
+
+	if params.Department != "" {
+		args = append(args, params.Department)
+		conditions = append(conditions, fmt.Sprintf("department = %s", r.ph(len(args))))
+	}
+	if params.Query != "" {
+		pattern := "%" + params.Query + "%"
+		args = append(args, pattern)
+		likeOp := "ILIKE"
+		namePh := r.ph(len(args))
+		emailPh := namePh
+		if r.driver == DriverMySQL {
+			// MySQL's ? placeholders are positional, so the pattern is bound once per use.
+			likeOp = "LIKE"
+			args = append(args, pattern)
+			emailPh = r.ph(len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf("(name %s %s OR email %s %s)", likeOp, namePh, likeOp, emailPh))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	countQuery = "SELECT COUNT(*) FROM users" + where
+
+	listArgs = append(append([]interface{}{}, args...), params.Limit, params.Offset)
+	listQuery = fmt.Sprintf(
+		"SELECT id, name, department, email, created_at, updated_at FROM users%s ORDER BY %s %s LIMIT %s OFFSET %s",
+		where, column, order, r.ph(len(listArgs)-1), r.ph(len(listArgs)),
+	)
+
+	return countQuery, args, listQuery, listArgs, nil
+}
+
+func (r *userRepository) List(ctx context.Context, params ListUsersParams) ([]models.User, int, error) {
+	countQuery, countArgs, listQuery, listArgs, err := r.buildListQuery(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, listQuery, listArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(&u.ID, &u.Name, &u.Department, &u.Email, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, 0, err
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id int) (*models.User, error) {
+	query := fmt.Sprintf(
+		`SELECT id, name, department, email, password, created_at, updated_at FROM users WHERE id = %s`, r.ph(1),
+	)
+	var u models.User
+	err := r.db.QueryRowContext(ctx, query, id).
+		Scan(&u.ID, &u.Name, &u.Department, &u.Email, &u.Password, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	query := fmt.Sprintf(
+		`SELECT id, name, department, email, password, created_at, updated_at FROM users WHERE email = %s`, r.ph(1),
+	)
+	var u models.User
+	err := r.db.QueryRowContext(ctx, query, email).
+		Scan(&u.ID, &u.Name, &u.Department, &u.Email, &u.Password, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	query := fmt.Sprintf(
+		`UPDATE users SET name = %s, department = %s, email = %s, updated_at = now() WHERE id = %s`,
+		r.ph(1), r.ph(2), r.ph(3), r.ph(4),
+	)
+	result, err := r.db.ExecContext(ctx, query, user.Name, user.Department, user.Email, user.ID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id int) error {
+	query := fmt.Sprintf(`DELETE FROM users WHERE id = %s`, r.ph(1))
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
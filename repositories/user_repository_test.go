@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"strings"
+	"testing"
+)
+
+func newTestRepo(driver string) *userRepository {
+	return &userRepository{driver: driver, ph: placeholderFunc(driver)}
+}
+
+func TestBuildCreateQuery_Postgres(t *testing.T) {
+	query := newTestRepo(DriverPostgres).buildCreateQuery()
+
+	if !strings.Contains(query, "$1") || !strings.Contains(query, "$4") {
+		t.Errorf("expected $-style placeholders, got: %s", query)
+	}
+	if !strings.Contains(query, "RETURNING id") {
+		t.Errorf("expected Postgres query to use RETURNING id, got: %s", query)
+	}
+}
+
+func TestBuildCreateQuery_MySQL(t *testing.T) {
+	query := newTestRepo(DriverMySQL).buildCreateQuery()
+
+	if strings.Contains(query, "$") {
+		t.Errorf("expected no $-style placeholders, got: %s", query)
+	}
+	if strings.Contains(query, "RETURNING") {
+		t.Errorf("MySQL doesn't support RETURNING, got: %s", query)
+	}
+	if got, want := strings.Count(query, "?"), 4; got != want {
+		t.Errorf("expected %d ? placeholders, got %d in: %s", want, got, query)
+	}
+}
+
+func TestBuildListQuery_InvalidSortColumn(t *testing.T) {
+	_, _, _, _, err := newTestRepo(DriverPostgres).buildListQuery(ListUsersParams{SortColumn: "id; DROP TABLE users", SortOrder: "asc"})
+	if err != ErrInvalidSortColumn {
+		t.Fatalf("expected ErrInvalidSortColumn, got %v", err)
+	}
+}
+
+func TestBuildListQuery_InvalidSortOrder(t *testing.T) {
+	_, _, _, _, err := newTestRepo(DriverPostgres).buildListQuery(ListUsersParams{SortColumn: "id", SortOrder: "sideways"})
+	if err != ErrInvalidSortOrder {
+		t.Fatalf("expected ErrInvalidSortOrder, got %v", err)
+	}
+}
+
+func TestBuildListQuery_Postgres(t *testing.T) {
+	params := ListUsersParams{SortColumn: "name", SortOrder: "desc", Department: "Engineering", Query: "alice", Limit: 50, Offset: 10}
+	countQuery, countArgs, listQuery, listArgs, err := newTestRepo(DriverPostgres).buildListQuery(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(countQuery, "department = $1") {
+		t.Errorf("expected department filter with $1, got: %s", countQuery)
+	}
+	if !strings.Contains(countQuery, "ILIKE $2") {
+		t.Errorf("expected ILIKE search with $2, got: %s", countQuery)
+	}
+	if len(countArgs) != 2 {
+		t.Errorf("expected 2 count args, got %d: %v", len(countArgs), countArgs)
+	}
+
+	if !strings.Contains(listQuery, "ORDER BY name desc") {
+		t.Errorf("expected ORDER BY name desc, got: %s", listQuery)
+	}
+	if !strings.Contains(listQuery, "LIMIT $3 OFFSET $4") {
+		t.Errorf("expected LIMIT $3 OFFSET $4, got: %s", listQuery)
+	}
+	if len(listArgs) != 4 {
+		t.Errorf("expected 4 list args (department, query, limit, offset), got %d: %v", len(listArgs), listArgs)
+	}
+}
+
+func TestBuildListQuery_MySQL(t *testing.T) {
+	params := ListUsersParams{SortColumn: "email", SortOrder: "asc", Query: "bob", Limit: 20, Offset: 0}
+	countQuery, _, listQuery, listArgs, err := newTestRepo(DriverMySQL).buildListQuery(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(countQuery, "LIKE ?") || strings.Contains(countQuery, "ILIKE") {
+		t.Errorf("expected plain LIKE (no ILIKE) for MySQL, got: %s", countQuery)
+	}
+	if !strings.Contains(listQuery, "LIMIT ? OFFSET ?") {
+		t.Errorf("expected ? placeholders in LIMIT/OFFSET, got: %s", listQuery)
+	}
+	if len(listArgs) != 4 {
+		t.Errorf("expected 4 list args (name query, email query, limit, offset), got %d: %v", len(listArgs), listArgs)
+	}
+}
+
+func TestBuildListQuery_NoFilters(t *testing.T) {
+	countQuery, countArgs, _, _, err := newTestRepo(DriverPostgres).buildListQuery(ListUsersParams{SortColumn: "id", SortOrder: "asc", Limit: 50, Offset: 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(countQuery, "WHERE") {
+		t.Errorf("expected no WHERE clause without filters, got: %s", countQuery)
+	}
+	if len(countArgs) != 0 {
+		t.Errorf("expected no count args without filters, got: %v", countArgs)
+	}
+}